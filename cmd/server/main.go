@@ -1,19 +1,76 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
 	"net/http"
+	"os"
 
-	// "github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
+	"github.com/rs/zerolog/log"
 
+	"github.com/g-e-e-z/orc/internal/engine"
 	"github.com/g-e-e-z/orc/internal/routes"
+	"github.com/g-e-e-z/orc/internal/server"
+	"github.com/g-e-e-z/orc/pkg/config"
 )
 
 func main() {
-	r := routes.NewRouter()
-	r.Use(middleware.Logger)
+	// First pass: pull just -config so we know what file (if any) to load
+	// before registering the rest of the flags against it.
+	pre := flag.NewFlagSet("orc", flag.ContinueOnError)
+	pre.SetOutput(io.Discard)
+	configPath := pre.String("config", "", "path to a YAML or TOML config file")
+	pre.Bool("dump-config", false, "print the effective config and exit")
+	// Register the remaining flags on a throwaway config too, so that a
+	// -config given after any of them is still reached by this pass.
+	config.NewDefault().RegisterFlags(pre)
+	pre.Parse(os.Args[1:])
+
+	var paths []string
+	if *configPath != "" {
+		paths = []string{*configPath}
+	}
+
+	cfg, err := config.Load(paths...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fs := flag.NewFlagSet("orc", flag.ExitOnError)
+	cfg.RegisterFlags(fs)
+	dumpConfig := fs.Bool("dump-config", false, "print the effective config and exit")
+	fs.String("config", *configPath, "path to a YAML or TOML config file")
+	fs.Parse(os.Args[1:])
+
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *dumpConfig {
+		cfg.Dump(os.Stdout)
+		return
+	}
+
+	pool := engine.New(cfg.Engine, cfg.Cluster.NodeID)
+	pool.Register("echo", engine.EchoTaskFactory)
+
+	r, err := routes.NewRouterWithDefaults(cfg, pool)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("welcome"))
 	})
-	http.ListenAndServe(":3000", r)
+
+	srv := server.New(cfg.Server, r)
+	srv.RegisterOnShutdown(pool.Shutdown)
+
+	if err := srv.Run(context.Background()); err != nil {
+		log.Fatal().Err(err).Msg("server exited with error")
+	}
 }
@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Claims is the set of identity attributes carried by a verified request.
+type Claims struct {
+	Subject string
+	Roles   []string
+}
+
+// HasRole reports whether the claims include role.
+func (c Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Verifier validates a bearer token and extracts its Claims.
+type Verifier interface {
+	Verify(token string) (Claims, error)
+}
+
+type ctxKey struct{}
+
+// FromContext returns the Claims stashed by Basic or JWT, if any.
+func FromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(ctxKey{}).(Claims)
+	return claims, ok
+}
+
+func withClaims(r *http.Request, claims Claims) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), ctxKey{}, claims))
+}
+
+// Basic returns middleware enforcing HTTP Basic Authentication against a
+// static username/password table. roles looks up the authenticated
+// user's Claims.Roles, so RequireRole can gate routes behind Basic just
+// as it does behind JWT; a user absent from roles simply has none.
+func Basic(users map[string]string, roles map[string][]string, realm string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := r.BasicAuth()
+			if !ok || !validUser(users, user, pass) {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Basic realm=%q`, realm))
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, withClaims(r, Claims{Subject: user, Roles: roles[user]}))
+		})
+	}
+}
+
+func validUser(users map[string]string, user, pass string) bool {
+	want, ok := users[user]
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(want), []byte(pass)) == 1
+}
+
+// JWT returns middleware that authenticates requests using a bearer token
+// from the Authorization header, verified by verifier.
+func JWT(verifier Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := bearerToken(r)
+			if token == "" {
+				http.Error(w, "missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, withClaims(r, claims))
+		})
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(h, prefix)
+}
+
+// RequireRole returns middleware that rejects requests whose context
+// Claims do not include role. It must run after Basic or JWT.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := FromContext(r.Context())
+			if !ok || !claims.HasRole(role) {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
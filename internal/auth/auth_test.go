@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestBasicAuth(t *testing.T) {
+	users := map[string]string{"alice": "s3cret"}
+	roles := map[string][]string{"alice": {"admin"}}
+
+	mw := Basic(users, roles, "test")
+	var gotClaims Claims
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotClaims, _ = FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Run("valid credentials carry roles", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("alice", "s3cret")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		if gotClaims.Subject != "alice" || !gotClaims.HasRole("admin") {
+			t.Fatalf("claims = %+v, want Subject=alice with role admin", gotClaims)
+		}
+	})
+
+	t.Run("wrong password rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("alice", "wrong")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("unknown user rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("mallory", "anything")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("missing credentials rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+}
+
+func TestRequireRoleAfterBasic(t *testing.T) {
+	users := map[string]string{"alice": "s3cret", "bob": "hunter2"}
+	roles := map[string][]string{"alice": {"admin"}}
+
+	mw := Basic(users, roles, "test")
+	protected := mw(RequireRole("admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	t.Run("user with role allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("alice", "s3cret")
+		rec := httptest.NewRecorder()
+		protected.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("user without role forbidden", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.SetBasicAuth("bob", "hunter2")
+		rec := httptest.NewRecorder()
+		protected.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+		}
+	})
+}
+
+func signHS256(t *testing.T, secret []byte, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	return signed
+}
+
+func TestJWTAuth(t *testing.T) {
+	secret := []byte("shh")
+	verifier := HMACVerifier{Secret: secret}
+	mw := JWT(verifier)
+
+	var gotClaims Claims
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotClaims, _ = FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Run("valid token carries subject and roles", func(t *testing.T) {
+		token := signHS256(t, secret, jwt.MapClaims{
+			"sub":   "alice",
+			"roles": []interface{}{"admin"},
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		if gotClaims.Subject != "alice" || !gotClaims.HasRole("admin") {
+			t.Fatalf("claims = %+v, want Subject=alice with role admin", gotClaims)
+		}
+	})
+
+	t.Run("missing bearer token rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("wrong secret rejected", func(t *testing.T) {
+		token := signHS256(t, []byte("different-secret"), jwt.MapClaims{"sub": "alice"})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("RS256-signed token rejected by HMAC verifier (algorithm confusion)", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("generating RSA key: %v", err)
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "alice"})
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("signing RS256 token: %v", err)
+		}
+
+		// HMACVerifier's keyfunc rejects any non-HMAC signing method
+		// before ever consulting the shared secret, so a validly-signed
+		// RS256 token must still be refused rather than treated as
+		// trusted because it parses.
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+signed)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// HMACVerifier verifies HS256-signed tokens against a shared secret.
+type HMACVerifier struct {
+	Secret []byte
+}
+
+func (v HMACVerifier) Verify(token string) (Claims, error) {
+	return verify(token, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("auth: unexpected signing method %v", t.Header["alg"])
+		}
+		return v.Secret, nil
+	})
+}
+
+// RSAVerifier verifies RS256-signed tokens against a public key.
+type RSAVerifier struct {
+	PublicKey *rsa.PublicKey
+}
+
+func (v RSAVerifier) Verify(token string) (Claims, error) {
+	return verify(token, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("auth: unexpected signing method %v", t.Header["alg"])
+		}
+		return v.PublicKey, nil
+	})
+}
+
+func verify(tokenStr string, keyFunc jwt.Keyfunc) (Claims, error) {
+	claims := jwt.MapClaims{}
+	parsed, err := jwt.ParseWithClaims(tokenStr, claims, keyFunc)
+	if err != nil || !parsed.Valid {
+		return Claims{}, fmt.Errorf("auth: invalid token: %w", err)
+	}
+
+	out := Claims{}
+	if sub, ok := claims["sub"].(string); ok {
+		out.Subject = sub
+	}
+	if roles, ok := claims["roles"].([]interface{}); ok {
+		for _, r := range roles {
+			if s, ok := r.(string); ok {
+				out.Roles = append(out.Roles, s)
+			}
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,391 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/g-e-e-z/orc/pkg/config"
+)
+
+// queueFactor sizes the pool's buffered queue relative to WorkerCount so a
+// burst of submissions doesn't immediately block callers.
+const queueFactor = 16
+
+var (
+	// ErrClosed is returned by Submit once the pool has started shutting
+	// down.
+	ErrClosed = errors.New("engine: pool is shut down")
+	// ErrNotFound is returned by Job, Wait and Cancel for an unknown
+	// JobID.
+	ErrNotFound = errors.New("engine: job not found")
+)
+
+// JobID uniquely identifies a submitted task.
+type JobID string
+
+// NodeIDSeparator separates a JobID's owning-node prefix from its unique
+// suffix, e.g. "node-1:3fa85f64-...", when the Pool is constructed with a
+// non-empty nodeID. internal/forwarder reads this prefix to route job
+// requests to the node that actually created (and holds) the job, rather
+// than recomputing ownership independently.
+const NodeIDSeparator = ":"
+
+// Task is a unit of work submitted to a Pool.
+type Task func(ctx context.Context) (Result, error)
+
+// Result is the value returned by a completed Task.
+type Result interface{}
+
+// Status is the lifecycle state of a Job.
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+)
+
+// Job tracks the state of a submitted task. Its mutable fields are
+// written by the worker goroutine that runs it and read concurrently by
+// HTTP handlers, so they're guarded by mu; callers outside this package
+// must go through Snapshot rather than touching fields directly.
+type Job struct {
+	ID       JobID
+	SubmitAt time.Time
+
+	mu       sync.Mutex
+	status   Status
+	result   Result
+	err      error
+	startAt  time.Time
+	endAt    time.Time
+	cancel   context.CancelFunc
+	canceled bool
+
+	done chan struct{}
+}
+
+func newJob(id JobID) *Job {
+	return &Job{
+		ID:       id,
+		SubmitAt: time.Now(),
+		status:   StatusQueued,
+		done:     make(chan struct{}),
+	}
+}
+
+// JobSnapshot is an immutable, JSON-serializable view of a Job's state at
+// the moment it was taken.
+type JobSnapshot struct {
+	ID       JobID     `json:"id"`
+	Status   Status    `json:"status"`
+	Result   Result    `json:"result,omitempty"`
+	Err      string    `json:"error,omitempty"`
+	SubmitAt time.Time `json:"submit_at"`
+	StartAt  time.Time `json:"start_at,omitempty"`
+	EndAt    time.Time `json:"end_at,omitempty"`
+}
+
+// Snapshot returns a point-in-time copy of the job's state, safe to read
+// or marshal without racing the worker that's running it.
+func (j *Job) Snapshot() JobSnapshot {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	snap := JobSnapshot{
+		ID:       j.ID,
+		Status:   j.status,
+		Result:   j.result,
+		SubmitAt: j.SubmitAt,
+		StartAt:  j.startAt,
+		EndAt:    j.endAt,
+	}
+	if j.err != nil {
+		snap.Err = j.err.Error()
+	}
+	return snap
+}
+
+func (j *Job) resultAndErr() (Result, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.result, j.err
+}
+
+func (j *Job) cancelFunc() context.CancelFunc {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.cancel
+}
+
+// markCanceled flags the job as canceled so run() skips it if it's still
+// queued, in addition to invoking cancel if the job is already running.
+func (j *Job) markCanceled() context.CancelFunc {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.canceled = true
+	return j.cancel
+}
+
+// isCanceled reports whether the job was canceled before a worker started
+// running it.
+func (j *Job) isCanceled() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.canceled
+}
+
+func (j *Job) markRunning(cancel context.CancelFunc) {
+	j.mu.Lock()
+	j.status = StatusRunning
+	j.startAt = time.Now()
+	j.cancel = cancel
+	j.mu.Unlock()
+}
+
+func (j *Job) finish(status Status, result Result, err error) {
+	j.mu.Lock()
+	j.status = status
+	j.result = result
+	j.err = err
+	j.endAt = time.Now()
+	j.mu.Unlock()
+	close(j.done)
+}
+
+// Stats is a point-in-time snapshot of pool activity.
+type Stats struct {
+	Queued    int
+	Running   int
+	Completed int
+	Failed    int
+}
+
+// Pool is a bounded worker pool that executes submitted Tasks with
+// per-task timeouts and panic recovery.
+type Pool struct {
+	cfg       config.EngineConfig
+	nodeID    string
+	queue     chan *submission
+	jobs      sync.Map // JobID -> *Job
+	factories sync.Map // string -> TaskFactory
+	wg        sync.WaitGroup
+
+	queued    int64
+	running   int64
+	completed int64
+	failed    int64
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+type submission struct {
+	job     *Job
+	task    Task
+	timeout time.Duration
+}
+
+// New starts cfg.WorkerCount workers and returns a ready Pool. nodeID, if
+// non-empty, is embedded in every minted JobID (see NodeIDSeparator) so
+// internal/forwarder can route job requests to the node that created
+// them.
+func New(cfg config.EngineConfig, nodeID string) *Pool {
+	p := &Pool{
+		cfg:    cfg,
+		nodeID: nodeID,
+		queue:  make(chan *submission, cfg.WorkerCount*queueFactor),
+		closed: make(chan struct{}),
+	}
+
+	for i := 0; i < cfg.WorkerCount; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+
+	return p
+}
+
+func (p *Pool) newJobID() JobID {
+	id := uuid.NewString()
+	if p.nodeID == "" {
+		return JobID(id)
+	}
+	return JobID(p.nodeID + NodeIDSeparator + id)
+}
+
+// SubmitOption customizes a single Submit call.
+type SubmitOption func(*submission)
+
+// WithTimeout overrides the pool's DefaultTimeout for a single submission.
+func WithTimeout(d time.Duration) SubmitOption {
+	return func(s *submission) { s.timeout = d }
+}
+
+// Submit enqueues task and returns its JobID immediately, or ctx.Err() if
+// ctx is canceled before the task is accepted onto the queue. ctx governs
+// only that enqueue attempt: once accepted, the task runs against its own
+// context.WithTimeout derived from the pool's DefaultTimeout (overridable
+// per-call via WithTimeout), deliberately decoupled from ctx so a queued
+// or running job outlives the request that submitted it.
+func (p *Pool) Submit(ctx context.Context, task Task, opts ...SubmitOption) (JobID, error) {
+	select {
+	case <-p.closed:
+		return "", ErrClosed
+	default:
+	}
+
+	job := newJob(p.newJobID())
+
+	s := &submission{
+		job:     job,
+		task:    task,
+		timeout: time.Duration(p.cfg.DefaultTimeout) * time.Second,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// Only register the job once it's actually on the queue: registering
+	// it first and then losing the select race to ctx.Done() or p.closed
+	// would leave an orphaned "queued" job that never reaches a worker.
+	select {
+	case p.queue <- s:
+		p.jobs.Store(job.ID, job)
+		atomic.AddInt64(&p.queued, 1)
+		return job.ID, nil
+	case <-p.closed:
+		return "", ErrClosed
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// Wait blocks until the job completes and returns its result.
+func (p *Pool) Wait(id JobID) (Result, error) {
+	job, err := p.Job(id)
+	if err != nil {
+		return nil, err
+	}
+	<-job.done
+	return job.resultAndErr()
+}
+
+// Job returns the Job tracking id. Callers outside this package should
+// read its state via Job.Snapshot rather than its unexported fields.
+func (p *Pool) Job(id JobID) (*Job, error) {
+	v, ok := p.jobs.Load(id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return v.(*Job), nil
+}
+
+// Cancel cancels a job. If the job is still queued, it's marked canceled
+// so run() skips it once dequeued; if it's already running, its context
+// is canceled immediately.
+func (p *Pool) Cancel(id JobID) error {
+	job, err := p.Job(id)
+	if err != nil {
+		return err
+	}
+	if cancel := job.markCanceled(); cancel != nil {
+		cancel()
+	}
+	return nil
+}
+
+// Stats returns a snapshot of queued/running/completed/failed counts.
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Queued:    int(atomic.LoadInt64(&p.queued)),
+		Running:   int(atomic.LoadInt64(&p.running)),
+		Completed: int(atomic.LoadInt64(&p.completed)),
+		Failed:    int(atomic.LoadInt64(&p.failed)),
+	}
+}
+
+// Shutdown stops accepting new tasks, signals workers to stop pulling
+// from the queue once their current task finishes, and waits for that to
+// happen or ctx to be canceled.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.closeOnce.Do(func() { close(p.closed) })
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) worker() {
+	defer p.wg.Done()
+	for {
+		select {
+		case s := <-p.queue:
+			p.run(s)
+		case <-p.closed:
+			return
+		}
+	}
+}
+
+func (p *Pool) run(s *submission) {
+	atomic.AddInt64(&p.queued, -1)
+
+	if s.job.isCanceled() {
+		s.job.finish(StatusCanceled, nil, context.Canceled)
+		return
+	}
+
+	atomic.AddInt64(&p.running, 1)
+	defer atomic.AddInt64(&p.running, -1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	s.job.markRunning(cancel)
+
+	// Cancel may have raced markRunning above: it could have read a nil
+	// cancel and skipped calling it, just before we stored the real one.
+	// Re-check and cancel ourselves so the job doesn't run to completion.
+	if s.job.isCanceled() {
+		cancel()
+	}
+
+	result, err := p.safeRun(ctx, s.task)
+
+	switch {
+	case err == nil:
+		s.job.finish(StatusCompleted, result, nil)
+		atomic.AddInt64(&p.completed, 1)
+	case errors.Is(err, context.Canceled):
+		s.job.finish(StatusCanceled, result, err)
+	default:
+		s.job.finish(StatusFailed, result, err)
+		atomic.AddInt64(&p.failed, 1)
+	}
+}
+
+func (p *Pool) safeRun(ctx context.Context, task Task) (result Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("engine: task panicked: %v", r)
+		}
+	}()
+	return task(ctx)
+}
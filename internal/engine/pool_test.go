@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/g-e-e-z/orc/pkg/config"
+)
+
+func testPool(workers int) *Pool {
+	return New(config.EngineConfig{
+		WorkerCount:    workers,
+		DefaultTimeout: 5,
+	}, "")
+}
+
+func TestSubmitWaitResult(t *testing.T) {
+	p := testPool(2)
+	t.Cleanup(func() { p.Shutdown(context.Background()) })
+
+	id, err := p.Submit(context.Background(), func(ctx context.Context) (Result, error) {
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	result, err := p.Wait(id)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if result != "done" {
+		t.Fatalf("Wait result = %v, want %q", result, "done")
+	}
+
+	snap := func() JobSnapshot {
+		job, err := p.Job(id)
+		if err != nil {
+			t.Fatalf("Job: %v", err)
+		}
+		return job.Snapshot()
+	}()
+	if snap.Status != StatusCompleted {
+		t.Fatalf("Status = %v, want %v", snap.Status, StatusCompleted)
+	}
+}
+
+func TestSubmitEmbedsNodeID(t *testing.T) {
+	p := New(config.EngineConfig{WorkerCount: 1, DefaultTimeout: 5}, "node-1")
+	t.Cleanup(func() { p.Shutdown(context.Background()) })
+
+	id, err := p.Submit(context.Background(), func(ctx context.Context) (Result, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	want := "node-1" + NodeIDSeparator
+	if !strings.HasPrefix(string(id), want) {
+		t.Fatalf("JobID = %q, want prefix %q", id, want)
+	}
+}
+
+func TestSubmitOutlivesCallerContext(t *testing.T) {
+	p := testPool(1)
+	t.Cleanup(func() { p.Shutdown(context.Background()) })
+
+	// Simulate an HTTP handler: its request context is canceled as soon as
+	// it returns, immediately after Submit enqueues the task.
+	callerCtx, cancel := context.WithCancel(context.Background())
+	id, err := p.Submit(callerCtx, func(ctx context.Context) (Result, error) {
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	cancel()
+
+	result, err := p.Wait(id)
+	if err != nil {
+		t.Fatalf("Wait err = %v, want nil; the task's context must not be tied to the caller's", err)
+	}
+	if result != "done" {
+		t.Fatalf("Wait result = %v, want %q", result, "done")
+	}
+}
+
+func TestShutdownIdle(t *testing.T) {
+	p := testPool(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := p.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown on an idle pool returned %v, want nil", err)
+	}
+}
+
+func TestCancelQueued(t *testing.T) {
+	p := testPool(1)
+	t.Cleanup(func() { p.Shutdown(context.Background()) })
+
+	block := make(chan struct{})
+	ranQueued := make(chan struct{}, 1)
+
+	// Occupy the single worker so the second submission stays queued.
+	_, err := p.Submit(context.Background(), func(ctx context.Context) (Result, error) {
+		<-block
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit (blocker): %v", err)
+	}
+
+	queuedID, err := p.Submit(context.Background(), func(ctx context.Context) (Result, error) {
+		ranQueued <- struct{}{}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit (queued): %v", err)
+	}
+
+	if err := p.Cancel(queuedID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	close(block)
+
+	result, err := p.Wait(queuedID)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Wait err = %v, want context.Canceled", err)
+	}
+	if result != nil {
+		t.Fatalf("Wait result = %v, want nil", result)
+	}
+
+	select {
+	case <-ranQueued:
+		t.Fatal("canceled queued job ran its task")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	job, err := p.Job(queuedID)
+	if err != nil {
+		t.Fatalf("Job: %v", err)
+	}
+	if got := job.Snapshot().Status; got != StatusCanceled {
+		t.Fatalf("Status = %v, want %v", got, StatusCanceled)
+	}
+}
+
+func TestPanicRecovery(t *testing.T) {
+	p := testPool(1)
+	t.Cleanup(func() { p.Shutdown(context.Background()) })
+
+	id, err := p.Submit(context.Background(), func(ctx context.Context) (Result, error) {
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	if _, err := p.Wait(id); err == nil {
+		t.Fatal("Wait err = nil, want non-nil after a panicking task")
+	}
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const defaultPageSize = 20
+
+// TaskFactory builds a Task from a raw JSON payload. Register task types
+// with (*Pool).Register so they can be submitted over the HTTP API.
+type TaskFactory func(payload json.RawMessage) (Task, error)
+
+// Register makes a task type submittable via POST /api/v1/jobs by name.
+func (p *Pool) Register(name string, factory TaskFactory) {
+	p.factories.Store(name, factory)
+}
+
+// Routes returns a chi.Router exposing the pool's job API:
+//
+//	POST   /        submit a new job ({"type": ..., "payload": ...})
+//	GET    /        list jobs, paginated via ?page=&page_size=
+//	GET    /{id}    fetch a job's status/result
+//	DELETE /{id}    cancel a running or queued job
+func (p *Pool) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Post("/", p.handleSubmit)
+	r.Get("/", p.handleList)
+	r.Get("/{id}", p.handleGet)
+	r.Delete("/{id}", p.handleCancel)
+	return r
+}
+
+type submitRequest struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type jobResponse struct {
+	ID     JobID  `json:"id"`
+	Status Status `json:"status"`
+}
+
+func (p *Pool) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req submitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	raw, ok := p.factories.Load(req.Type)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown task type %q", req.Type), http.StatusBadRequest)
+		return
+	}
+
+	task, err := raw.(TaskFactory)(req.Payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	id, err := p.Submit(r.Context(), task)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, jobResponse{ID: id, Status: StatusQueued})
+}
+
+func (p *Pool) handleGet(w http.ResponseWriter, r *http.Request) {
+	job, err := p.Job(JobID(chi.URLParam(r, "id")))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, job.Snapshot())
+}
+
+func (p *Pool) handleCancel(w http.ResponseWriter, r *http.Request) {
+	if err := p.Cancel(JobID(chi.URLParam(r, "id"))); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *Pool) handleList(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+
+	var all []JobSnapshot
+	p.jobs.Range(func(_, v interface{}) bool {
+		all = append(all, v.(*Job).Snapshot())
+		return true
+	})
+	sort.Slice(all, func(i, j int) bool { return all[i].SubmitAt.Before(all[j].SubmitAt) })
+
+	start := (page - 1) * pageSize
+	if start > len(all) {
+		start = len(all)
+	}
+	end := start + pageSize
+	if end > len(all) {
+		end = len(all)
+	}
+
+	writeJSON(w, http.StatusOK, all[start:end])
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
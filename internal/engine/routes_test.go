@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestSubmitAndGetOverHTTP(t *testing.T) {
+	p := testPool(1)
+	t.Cleanup(func() { p.Shutdown(context.Background()) })
+	p.Register("echo", EchoTaskFactory)
+
+	r := chi.NewRouter()
+	r.Mount("/", p.Routes())
+
+	body := strings.NewReader(`{"type":"echo","payload":{"message":"hi"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("POST / status = %d, want %d; body: %s", rec.Code, http.StatusAccepted, rec.Body)
+	}
+
+	var submitted jobResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &submitted); err != nil {
+		t.Fatalf("decoding submit response: %v", err)
+	}
+
+	var snap JobSnapshot
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		req := httptest.NewRequest(http.MethodGet, "/"+string(submitted.ID), nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET /%s status = %d, want %d; body: %s", submitted.ID, rec.Code, http.StatusOK, rec.Body)
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &snap); err != nil {
+			t.Fatalf("decoding job response: %v", err)
+		}
+		if snap.Status == StatusCompleted {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if snap.Status != StatusCompleted {
+		t.Fatalf("job Status = %v, want %v", snap.Status, StatusCompleted)
+	}
+	if snap.Result != "hi" {
+		t.Fatalf("job Result = %v, want %q", snap.Result, "hi")
+	}
+}
+
+func TestSubmitUnknownTaskType(t *testing.T) {
+	p := testPool(1)
+	t.Cleanup(func() { p.Shutdown(context.Background()) })
+
+	r := chi.NewRouter()
+	r.Mount("/", p.Routes())
+
+	body := strings.NewReader(`{"type":"does-not-exist","payload":{}}`)
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("POST / status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
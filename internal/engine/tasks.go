@@ -0,0 +1,26 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// EchoPayload is the payload for the built-in "echo" task type.
+type EchoPayload struct {
+	Message string `json:"message"`
+}
+
+// EchoTaskFactory builds a Task that immediately returns its decoded
+// payload's Message as the Result, without doing any real work. It's
+// registered by cmd/server as a smoke-testable example of the job API;
+// real deployments will register their own task types via Register.
+func EchoTaskFactory(payload json.RawMessage) (Task, error) {
+	var p EchoPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("engine: decoding echo payload: %w", err)
+	}
+	return func(ctx context.Context) (Result, error) {
+		return p.Message, nil
+	}, nil
+}
@@ -0,0 +1,48 @@
+package forwarder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/g-e-e-z/orc/internal/engine"
+	"github.com/g-e-e-z/orc/pkg/config"
+)
+
+// ClusterResolver resolves routing-key ownership across a statically
+// configured cluster by reading the owning node's ID directly off the
+// front of the key (a JobID minted by engine.Pool, see
+// engine.NodeIDSeparator) rather than recomputing ownership independently
+// — the node that created a job is the only node that actually holds it.
+type ClusterResolver struct {
+	nodeID string
+	peers  map[string]string // node ID -> URL, as configured (may include nodeID)
+}
+
+// NewClusterResolver builds a ClusterResolver from cfg. cfg.NodeID
+// identifies this node among cfg.Peers.
+func NewClusterResolver(cfg config.ClusterConfig) *ClusterResolver {
+	peers := make(map[string]string, len(cfg.Peers))
+	for _, p := range cfg.Peers {
+		peers[p.ID] = p.URL
+	}
+	return &ClusterResolver{nodeID: cfg.NodeID, peers: peers}
+}
+
+// Owner implements Resolver. A key without an owning-node prefix (no
+// engine.NodeIDSeparator, or an empty prefix), including the empty key used
+// for list/submit requests, which aren't owned by any single node, resolves
+// as local, as does a key prefixed with this node's ID. A prefix naming a
+// node with no configured peer URL is an error.
+func (r *ClusterResolver) Owner(_ context.Context, key string) (string, bool, error) {
+	owner, _, ok := strings.Cut(key, engine.NodeIDSeparator)
+	if !ok || owner == "" || owner == r.nodeID {
+		return "", true, nil
+	}
+
+	url, ok := r.peers[owner]
+	if !ok {
+		return "", false, fmt.Errorf("forwarder: no URL configured for peer %q", owner)
+	}
+	return url, false, nil
+}
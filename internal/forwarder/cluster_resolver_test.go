@@ -0,0 +1,83 @@
+package forwarder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/g-e-e-z/orc/internal/engine"
+	"github.com/g-e-e-z/orc/pkg/config"
+)
+
+func TestClusterResolverOwner(t *testing.T) {
+	cfg := config.ClusterConfig{
+		NodeID: "node-1",
+		Peers: []config.PeerConfig{
+			{ID: "node-1", URL: "http://node-1"},
+			{ID: "node-2", URL: "http://node-2"},
+		},
+	}
+	r := NewClusterResolver(cfg)
+
+	tests := []struct {
+		name      string
+		key       string
+		wantLocal bool
+		wantURL   string
+		wantErr   bool
+	}{
+		{"empty key (list/submit) is local", "", true, "", false},
+		{"no separator is local", "not-a-prefixed-id", true, "", false},
+		{"own node prefix is local", "node-1" + engine.NodeIDSeparator + "abc", true, "", false},
+		{"peer node prefix forwards", "node-2" + engine.NodeIDSeparator + "abc", false, "http://node-2", false},
+		{"unknown node prefix errors", "node-3" + engine.NodeIDSeparator + "abc", false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, local, err := r.Owner(context.Background(), tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if local != tt.wantLocal {
+				t.Fatalf("local = %v, want %v", local, tt.wantLocal)
+			}
+			if url != tt.wantURL {
+				t.Fatalf("url = %q, want %q", url, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestClusterResolverRoutesToCreatingNode(t *testing.T) {
+	// Regression: ownership must come from the JobID's embedded prefix,
+	// i.e. the node that actually created and holds the job, not from
+	// hashing the ID -- a hash has no relationship to where the job lives.
+	pool := engine.New(config.EngineConfig{WorkerCount: 1, DefaultTimeout: 5}, "node-2")
+	t.Cleanup(func() { pool.Shutdown(context.Background()) })
+
+	id, err := pool.Submit(context.Background(), func(ctx context.Context) (engine.Result, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+
+	r := NewClusterResolver(config.ClusterConfig{
+		NodeID: "node-1",
+		Peers: []config.PeerConfig{
+			{ID: "node-1", URL: "http://node-1"},
+			{ID: "node-2", URL: "http://node-2"},
+		},
+	})
+
+	url, local, err := r.Owner(context.Background(), string(id))
+	if err != nil {
+		t.Fatalf("Owner: %v", err)
+	}
+	if local {
+		t.Fatal("job created by node-2 should not resolve as local to node-1")
+	}
+	if url != "http://node-2" {
+		t.Fatalf("url = %q, want %q", url, "http://node-2")
+	}
+}
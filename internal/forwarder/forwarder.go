@@ -0,0 +1,113 @@
+// Package forwarder lets an orc node transparently proxy a request to the
+// peer node that actually owns the target job or tenant, following the
+// transaction-forwarder pattern used by distributed services that
+// shard ownership across nodes.
+package forwarder
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+)
+
+// HopHeader carries the number of times a request has already been
+// forwarded, used to detect and break forwarding loops.
+const HopHeader = "X-Orc-Forward-Hops"
+
+// DefaultMaxHops is used when a Forwarder is constructed without an
+// explicit hop limit.
+const DefaultMaxHops = 8
+
+// Resolver locates the node that owns a given routing key.
+type Resolver interface {
+	// Owner reports the URL of the node owning key, and whether this node
+	// is the owner (in which case nodeURL is unset).
+	Owner(ctx context.Context, key string) (nodeURL string, local bool, err error)
+}
+
+// Forwarder reverse-proxies requests to the node that owns them.
+type Forwarder struct {
+	resolver  Resolver
+	transport http.RoundTripper
+	maxHops   int
+}
+
+// New returns a Forwarder that resolves ownership via resolver and
+// proxies non-local requests using transport. A nil transport uses
+// http.DefaultTransport.
+func New(resolver Resolver, transport http.RoundTripper) *Forwarder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Forwarder{resolver: resolver, transport: transport, maxHops: DefaultMaxHops}
+}
+
+// WithMaxHops overrides the hop limit used to detect forwarding loops.
+func (f *Forwarder) WithMaxHops(n int) *Forwarder {
+	f.maxHops = n
+	return f
+}
+
+// Middleware returns chi-compatible middleware that forwards a request to
+// the node owning keyFn(r), or passes it through unchanged when this node
+// is the owner.
+func (f *Forwarder) Middleware(keyFn func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hops, err := hopCount(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if hops >= f.maxHops {
+				http.Error(w, "forwarder: max hop count exceeded", http.StatusLoopDetected)
+				return
+			}
+
+			ownerURL, local, err := f.resolver.Owner(r.Context(), keyFn(r))
+			if err != nil {
+				http.Error(w, fmt.Sprintf("forwarder: resolving owner: %v", err), http.StatusBadGateway)
+				return
+			}
+			if local {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			target, err := url.Parse(ownerURL)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("forwarder: invalid owner URL: %v", err), http.StatusBadGateway)
+				return
+			}
+
+			r.Header.Set(HopHeader, strconv.Itoa(hops+1))
+			f.proxy(target).ServeHTTP(w, r)
+		})
+	}
+}
+
+// proxy builds a ReverseProxy to target. httputil.ReverseProxy already
+// forwards the request/response body, end-to-end headers and trailers; it
+// drops only hop-by-hop headers (Connection, Upgrade and the like) and adds
+// X-Forwarded-For, so X-Request-ID and the hop header set above survive
+// the hop.
+func (f *Forwarder) proxy(target *url.URL) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = f.transport
+	return proxy
+}
+
+func hopCount(r *http.Request) (int, error) {
+	v := r.Header.Get(HopHeader)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("forwarder: invalid %s header: %w", HopHeader, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,101 @@
+package forwarder
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type stubResolver struct {
+	url   string
+	local bool
+	err   error
+}
+
+func (s stubResolver) Owner(context.Context, string) (string, bool, error) {
+	return s.url, s.local, s.err
+}
+
+func TestMiddlewareLocalPassesThrough(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	f := New(stubResolver{local: true}, nil)
+	handler := f.Middleware(func(*http.Request) string { return "any" })(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs/abc", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !called {
+		t.Fatal("local owner should pass the request through to next")
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestMiddlewareForwardsToOwner(t *testing.T) {
+	owner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Owner-Saw-Hops", r.Header.Get(HopHeader))
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	t.Cleanup(owner.Close)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("local handler should not run when a remote owner is found")
+	})
+
+	f := New(stubResolver{local: false, url: owner.URL}, nil)
+	handler := f.Middleware(func(*http.Request) string { return "peer-job" })(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs/peer-job", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("status = %d, want %d (proxied from owner)", rec.Code, http.StatusTeapot)
+	}
+	if got := rec.Header().Get("X-Owner-Saw-Hops"); got != "1" {
+		t.Fatalf("owner saw hop count %q, want %q", got, "1")
+	}
+}
+
+func TestMiddlewareHopLimit(t *testing.T) {
+	f := New(stubResolver{local: false, url: "http://127.0.0.1:1"}, nil).WithMaxHops(2)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run once the hop limit is reached")
+	})
+	handler := f.Middleware(func(*http.Request) string { return "job" })(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs/job", nil)
+	req.Header.Set(HopHeader, strconv.Itoa(2))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusLoopDetected {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusLoopDetected)
+	}
+}
+
+func TestMiddlewareResolverError(t *testing.T) {
+	f := New(stubResolver{err: fmt.Errorf("boom")}, nil)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run when ownership can't be resolved")
+	})
+	handler := f.Middleware(func(*http.Request) string { return "job" })(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs/job", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadGateway)
+	}
+}
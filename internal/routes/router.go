@@ -0,0 +1,189 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/g-e-e-z/orc/internal/auth"
+	"github.com/g-e-e-z/orc/internal/engine"
+	"github.com/g-e-e-z/orc/internal/forwarder"
+	"github.com/g-e-e-z/orc/pkg/config"
+)
+
+// NewRouter returns a bare chi.Mux with no middleware attached. Prefer
+// NewRouterWithDefaults for the production middleware pipeline; this is
+// mainly useful for tests that want to control the pipeline themselves.
+func NewRouter() *chi.Mux {
+	return chi.NewRouter()
+}
+
+// NewRouterWithDefaults returns a chi.Mux wired with a production-grade
+// middleware pipeline: request tracing, panic recovery, structured
+// request logging, compression and a request timeout. Individual stages
+// can be disabled via cfg.Server.Middleware. The engine's job API is
+// mounted at /api/v1/jobs, behind whatever auth scheme cfg.Auth selects;
+// "/" and "/healthz" stay public.
+func NewRouterWithDefaults(cfg *config.Config, pool *engine.Pool) (*chi.Mux, error) {
+	r := chi.NewRouter()
+	mw := cfg.Server.Middleware
+
+	if mw.RequestID {
+		r.Use(middleware.RequestID)
+	}
+	if mw.RealIP {
+		r.Use(middleware.RealIP)
+	}
+	if mw.Logger {
+		r.Use(structuredLogger(log.Logger))
+	}
+	if mw.Recoverer {
+		r.Use(middleware.Recoverer)
+	}
+	if mw.CleanPath {
+		r.Use(middleware.CleanPath)
+	}
+	if mw.StripSlashes {
+		r.Use(middleware.StripSlashes)
+	}
+	if mw.Heartbeat {
+		r.Use(middleware.Heartbeat("/healthz"))
+	}
+	if mw.Compress {
+		r.Use(middleware.Compress(5))
+	}
+	if mw.Timeout {
+		r.Use(middleware.Timeout(cfg.Server.RequestTimeout))
+	}
+
+	authMW, err := buildAuth(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Route("/api", func(api chi.Router) {
+		if authMW != nil {
+			api.Use(authMW)
+		}
+
+		api.Route("/v1", func(v1 chi.Router) {
+			fwd := forwarder.New(forwarder.NewClusterResolver(cfg.Cluster), nil)
+			if cfg.Server.MaxForwardHops > 0 {
+				fwd = fwd.WithMaxHops(cfg.Server.MaxForwardHops)
+			}
+			v1.With(fwd.Middleware(jobRoutingKey)).Mount("/jobs", pool.Routes())
+			v1.Get("/cluster/peers", handleClusterPeers(cfg.Cluster))
+		})
+
+		api.Group(func(admin chi.Router) {
+			admin.Use(auth.RequireRole("admin"))
+			admin.Get("/admin/status", handleAdminStatus)
+		})
+	})
+
+	return r, nil
+}
+
+func handleAdminStatus(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("ok"))
+}
+
+// jobRoutingKey extracts the job ID from a /api/v1/jobs/{id} request path,
+// or "" for requests with no single job target (list and submit), which
+// the forwarder always treats as local.
+func jobRoutingKey(r *http.Request) string {
+	const prefix = "/api/v1/jobs/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		return ""
+	}
+	id := strings.TrimSuffix(r.URL.Path[len(prefix):], "/")
+	if id == "" || strings.Contains(id, "/") {
+		return ""
+	}
+	return id
+}
+
+// handleClusterPeers serves the node's statically configured peer list
+// for discovery by other nodes.
+func handleClusterPeers(cluster config.ClusterConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cluster.Peers)
+	}
+}
+
+// buildAuth returns the middleware enforcing cfg's auth scheme, or nil if
+// cfg selects no auth (the zero value).
+func buildAuth(cfg config.AuthConfig) (func(http.Handler) http.Handler, error) {
+	switch cfg.Scheme {
+	case "", "none":
+		return nil, nil
+	case "basic":
+		return auth.Basic(cfg.BasicUsers, cfg.BasicRoles, cfg.Realm), nil
+	case "jwt":
+		verifier, err := newJWTVerifier(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("routes: building JWT verifier: %w", err)
+		}
+		return auth.JWT(verifier), nil
+	default:
+		return nil, fmt.Errorf("routes: unknown auth scheme %q", cfg.Scheme)
+	}
+}
+
+func newJWTVerifier(cfg config.AuthConfig) (auth.Verifier, error) {
+	switch strings.ToUpper(cfg.JWTAlgorithm) {
+	case "", "HS256":
+		if cfg.JWTSecret == "" {
+			return nil, errors.New("routes: JWTSecret is required for HS256")
+		}
+		return auth.HMACVerifier{Secret: []byte(cfg.JWTSecret)}, nil
+	case "RS256":
+		data, err := os.ReadFile(cfg.JWTPublicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, err
+		}
+		return auth.RSAVerifier{PublicKey: key}, nil
+	default:
+		return nil, fmt.Errorf("routes: unsupported JWT algorithm %q", cfg.JWTAlgorithm)
+	}
+}
+
+// structuredLogger builds a chi request logger that emits one JSON line
+// per request via zerolog, including the request ID, status, bytes
+// written and latency.
+func structuredLogger(logger zerolog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			defer func() {
+				logger.Info().
+					Str("request_id", middleware.GetReqID(r.Context())).
+					Str("method", r.Method).
+					Str("path", r.URL.Path).
+					Int("status", ww.Status()).
+					Int("bytes", ww.BytesWritten()).
+					Dur("latency", time.Since(start)).
+					Msg("request completed")
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
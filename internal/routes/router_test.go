@@ -0,0 +1,159 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/g-e-e-z/orc/internal/engine"
+	"github.com/g-e-e-z/orc/pkg/config"
+)
+
+func TestJobRoutingKey(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"submit path has no job target", "/api/v1/jobs", ""},
+		{"submit path with trailing slash", "/api/v1/jobs/", ""},
+		{"single job path extracts id", "/api/v1/jobs/abc123", "abc123"},
+		{"job path with trailing slash", "/api/v1/jobs/abc123/", "abc123"},
+		{"nested subpath is not a single job target", "/api/v1/jobs/abc123/logs", ""},
+		{"unrelated path", "/api/v1/cluster/peers", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if got := jobRoutingKey(r); got != tt.want {
+				t.Fatalf("jobRoutingKey(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildAuth(t *testing.T) {
+	t.Run("no scheme means no middleware", func(t *testing.T) {
+		mw, err := buildAuth(config.AuthConfig{})
+		if err != nil {
+			t.Fatalf("buildAuth: %v", err)
+		}
+		if mw != nil {
+			t.Fatal("expected nil middleware for the zero-value scheme")
+		}
+	})
+
+	t.Run("none scheme means no middleware", func(t *testing.T) {
+		mw, err := buildAuth(config.AuthConfig{Scheme: "none"})
+		if err != nil {
+			t.Fatalf("buildAuth: %v", err)
+		}
+		if mw != nil {
+			t.Fatal("expected nil middleware for scheme \"none\"")
+		}
+	})
+
+	t.Run("basic scheme builds middleware", func(t *testing.T) {
+		mw, err := buildAuth(config.AuthConfig{
+			Scheme:     "basic",
+			BasicUsers: map[string]string{"alice": "s3cret"},
+		})
+		if err != nil {
+			t.Fatalf("buildAuth: %v", err)
+		}
+		if mw == nil {
+			t.Fatal("expected non-nil middleware for scheme \"basic\"")
+		}
+	})
+
+	t.Run("jwt scheme without secret errors", func(t *testing.T) {
+		_, err := buildAuth(config.AuthConfig{Scheme: "jwt"})
+		if err == nil {
+			t.Fatal("expected an error when HS256 JWT is selected with no secret")
+		}
+	})
+
+	t.Run("jwt scheme with secret builds middleware", func(t *testing.T) {
+		mw, err := buildAuth(config.AuthConfig{Scheme: "jwt", JWTSecret: "shh"})
+		if err != nil {
+			t.Fatalf("buildAuth: %v", err)
+		}
+		if mw == nil {
+			t.Fatal("expected non-nil middleware for scheme \"jwt\"")
+		}
+	})
+
+	t.Run("unknown scheme errors", func(t *testing.T) {
+		_, err := buildAuth(config.AuthConfig{Scheme: "hmac"})
+		if err == nil {
+			t.Fatal("expected an error for an unrecognized auth scheme")
+		}
+	})
+}
+
+func newTestConfig() *config.Config {
+	return &config.Config{
+		Server: config.ServerConfig{
+			Middleware: config.MiddlewareConfig{
+				RequestID: true,
+				Recoverer: true,
+				Heartbeat: true,
+			},
+			RequestTimeout: 0,
+		},
+		Cluster: config.ClusterConfig{NodeID: "node-1"},
+	}
+}
+
+func TestNewRouterWithDefaultsMountsJobsAndHealthz(t *testing.T) {
+	pool := engine.New(config.EngineConfig{WorkerCount: 1, DefaultTimeout: 5}, "node-1")
+	t.Cleanup(func() { pool.Shutdown(context.Background()) })
+	pool.Register("echo", engine.EchoTaskFactory)
+
+	r, err := NewRouterWithDefaults(newTestConfig(), pool)
+	if err != nil {
+		t.Fatalf("NewRouterWithDefaults: %v", err)
+	}
+
+	t.Run("healthz is public", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("jobs API is reachable with no auth configured", func(t *testing.T) {
+		body := `{"type":"echo","payload":{"message":"hi"}}`
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/jobs", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("status = %d, want %d, body %s", rec.Code, http.StatusAccepted, rec.Body.String())
+		}
+	})
+
+	t.Run("admin route requires a role", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/status", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+		}
+	})
+}
+
+func TestNewRouterWithDefaultsUnknownAuthSchemeErrors(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Auth.Scheme = "bogus"
+	pool := engine.New(config.EngineConfig{WorkerCount: 1, DefaultTimeout: 5}, "node-1")
+	t.Cleanup(func() { pool.Shutdown(context.Background()) })
+
+	if _, err := NewRouterWithDefaults(cfg, pool); err == nil {
+		t.Fatal("expected an error for an unknown auth scheme")
+	}
+}
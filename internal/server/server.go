@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/g-e-e-z/orc/pkg/config"
+)
+
+// ShutdownHook is invoked during graceful shutdown so subsystems (such as
+// the engine worker pool) can drain in-flight work before the process
+// exits. Hooks must respect the passed context's deadline.
+type ShutdownHook func(context.Context) error
+
+// Server wraps an *http.Server with a signal-driven graceful shutdown
+// lifecycle.
+type Server struct {
+	cfg     config.ServerConfig
+	httpSrv *http.Server
+
+	inFlight int64
+
+	mu    sync.Mutex
+	hooks []ShutdownHook
+}
+
+// New returns a Server that serves handler according to cfg.
+func New(cfg config.ServerConfig, handler http.Handler) *Server {
+	s := &Server{cfg: cfg}
+	s.httpSrv = &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Port),
+		Handler: s.countInFlight(handler),
+	}
+	return s
+}
+
+// RegisterOnShutdown registers a hook to run during graceful shutdown.
+// Hooks run concurrently with each other and with the HTTP server's drain,
+// all against the same shutdown deadline.
+func (s *Server) RegisterOnShutdown(hook ShutdownHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, hook)
+}
+
+// Run starts the HTTP server and blocks until ctx is canceled or a
+// SIGINT/SIGTERM is received, at which point it attempts a graceful
+// shutdown within cfg.ShutdownTimeout. It returns a non-nil error if the
+// server fails to start or the shutdown deadline elapses.
+func (s *Server) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := s.httpSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+
+	log.Info().
+		Int64("in_flight_requests", atomic.LoadInt64(&s.inFlight)).
+		Msg("shutting down: draining in-flight requests and hooks concurrently")
+
+	// Drain the HTTP server and run shutdown hooks concurrently against
+	// the same deadline rather than serially, so a slow hook can't burn
+	// the whole budget before httpSrv.Shutdown even starts.
+	var hookErr, httpErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		hookErr = s.runHooks(shutdownCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		httpErr = s.httpSrv.Shutdown(shutdownCtx)
+	}()
+	wg.Wait()
+
+	if hookErr != nil {
+		log.Error().Err(hookErr).Msg("shutdown hook failed")
+	}
+	if httpErr != nil {
+		log.Error().Err(httpErr).Msg("graceful shutdown deadline exceeded")
+		return httpErr
+	}
+
+	log.Info().Msg("shutdown complete")
+	return nil
+}
+
+func (s *Server) runHooks(ctx context.Context) error {
+	s.mu.Lock()
+	hooks := append([]ShutdownHook(nil), s.hooks...)
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(hooks))
+	for _, hook := range hooks {
+		wg.Add(1)
+		go func(hook ShutdownHook) {
+			defer wg.Done()
+			if err := hook(ctx); err != nil {
+				errCh <- err
+			}
+		}(hook)
+	}
+	wg.Wait()
+	close(errCh)
+
+	var errs []error
+	for err := range errCh {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Server) countInFlight(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&s.inFlight, 1)
+		defer atomic.AddInt64(&s.inFlight, -1)
+		next.ServeHTTP(w, r)
+	})
+}
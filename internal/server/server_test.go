@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/g-e-e-z/orc/pkg/config"
+)
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening for a free port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestRunShutsDownOnContextCancel(t *testing.T) {
+	cfg := config.ServerConfig{Port: freePort(t), ShutdownTimeout: time.Second}
+	s := New(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Run(ctx) }()
+
+	waitForListening(t, cfg.Port)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("Run returned %v, want nil", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after context cancellation")
+	}
+}
+
+func TestRunDrainsHooksConcurrentlyWithHTTP(t *testing.T) {
+	// Regression: hooks and the HTTP drain must run against the same
+	// deadline concurrently, not serially, so a slow hook can't starve
+	// httpSrv.Shutdown (or vice versa) of the shared budget.
+	cfg := config.ServerConfig{Port: freePort(t), ShutdownTimeout: 200 * time.Millisecond}
+
+	blockRequest := make(chan struct{})
+	requestStarted := make(chan struct{})
+	s := New(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestStarted)
+		<-blockRequest
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	var hookStarted, hookDone time.Time
+	var mu sync.Mutex
+	s.RegisterOnShutdown(func(ctx context.Context) error {
+		mu.Lock()
+		hookStarted = time.Now()
+		mu.Unlock()
+		select {
+		case <-ctx.Done():
+		case <-time.After(50 * time.Millisecond):
+		}
+		mu.Lock()
+		hookDone = time.Now()
+		mu.Unlock()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Run(ctx) }()
+
+	waitForListening(t, cfg.Port)
+
+	var resp *http.Response
+	var getErr error
+	reqDone := make(chan struct{})
+	go func() {
+		resp, getErr = http.Get("http://127.0.0.1:" + portStr(cfg.Port) + "/")
+		close(reqDone)
+	}()
+	<-requestStarted
+
+	shutdownStart := time.Now()
+	cancel()
+	close(blockRequest)
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("Run returned %v, want nil", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return")
+	}
+	<-reqDone
+	if getErr != nil {
+		t.Fatalf("in-flight request failed during shutdown: %v", getErr)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("in-flight request status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if hookStarted.IsZero() || hookDone.IsZero() {
+		t.Fatal("shutdown hook never ran")
+	}
+	if hookStarted.Sub(shutdownStart) > 100*time.Millisecond {
+		t.Fatalf("hook started %v after shutdown began, want it to start immediately alongside the HTTP drain", hookStarted.Sub(shutdownStart))
+	}
+}
+
+func TestRunSurvivesHookFailure(t *testing.T) {
+	cfg := config.ServerConfig{Port: freePort(t), ShutdownTimeout: 10 * time.Millisecond}
+	s := New(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	s.RegisterOnShutdown(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Run(ctx) }()
+
+	waitForListening(t, cfg.Port)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("Run returned %v, want nil (a slow hook must not fail Run, only the HTTP deadline does)", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return")
+	}
+}
+
+func waitForListening(t *testing.T, port int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		conn, err := net.Dial("tcp", "127.0.0.1:"+portStr(port))
+		if err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("server never started listening on port %d", port)
+}
+
+func portStr(port int) string {
+	return strconv.Itoa(port)
+}
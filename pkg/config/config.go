@@ -7,6 +7,8 @@ type Config struct {
     Database DatabaseConfig
     Server   ServerConfig
     Engine   EngineConfig
+    Auth     AuthConfig
+    Cluster  ClusterConfig
 }
 
 type DatabaseConfig struct {
@@ -20,6 +22,24 @@ type DatabaseConfig struct {
 type ServerConfig struct {
     Port            int
     ShutdownTimeout time.Duration
+    RequestTimeout  time.Duration
+    Middleware      MiddlewareConfig
+    MaxForwardHops  int // loop guard for internal/forwarder.Forwarder
+}
+
+// MiddlewareConfig toggles individual stages of the default HTTP
+// middleware pipeline built by routes.NewRouterWithDefaults. Disabling a
+// stage is mainly useful for tests that want a lighter-weight router.
+type MiddlewareConfig struct {
+    RequestID    bool
+    RealIP       bool
+    Recoverer    bool
+    CleanPath    bool
+    StripSlashes bool
+    Heartbeat    bool
+    Compress     bool
+    Timeout      bool
+    Logger       bool
 }
 
 type EngineConfig struct {
@@ -27,3 +47,29 @@ type EngineConfig struct {
     DefaultTimeout int // seconds
 }
 
+// AuthConfig selects and configures the auth scheme routes.NewRouterWithDefaults
+// enforces on /api. Scheme is one of "", "none", "basic" or "jwt".
+type AuthConfig struct {
+    Scheme     string
+    Realm      string
+    BasicUsers map[string]string
+    BasicRoles map[string][]string // username -> roles, for auth.RequireRole
+
+    JWTAlgorithm     string // "HS256" (default) or "RS256"
+    JWTSecret        string // required for HS256
+    JWTPublicKeyPath string // required for RS256
+}
+
+// ClusterConfig seeds peer discovery for internal/forwarder, exposed over
+// the /api/v1/cluster/peers endpoint.
+type ClusterConfig struct {
+    NodeID string
+    Peers  []PeerConfig
+}
+
+// PeerConfig identifies a single peer node reachable by the forwarder.
+type PeerConfig struct {
+    ID  string
+    URL string
+}
+
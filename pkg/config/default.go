@@ -0,0 +1,38 @@
+package config
+
+import "time"
+
+// NewDefault returns a Config populated with sensible zero-conf defaults
+// suitable for local development. Load starts from this before overlaying
+// a file and the environment.
+func NewDefault() *Config {
+	return &Config{
+		Database: DatabaseConfig{
+			Host:   "localhost",
+			Port:   5432,
+			User:   "orc",
+			DBName: "orc",
+		},
+		Server: ServerConfig{
+			Port:            3000,
+			ShutdownTimeout: 10 * time.Second,
+			RequestTimeout:  60 * time.Second,
+			MaxForwardHops:  8,
+			Middleware: MiddlewareConfig{
+				RequestID:    true,
+				RealIP:       true,
+				Recoverer:    true,
+				CleanPath:    true,
+				StripSlashes: true,
+				Heartbeat:    true,
+				Compress:     true,
+				Timeout:      true,
+				Logger:       true,
+			},
+		},
+		Engine: EngineConfig{
+			WorkerCount:    4,
+			DefaultTimeout: 30,
+		},
+	}
+}
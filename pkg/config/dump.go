@@ -0,0 +1,34 @@
+package config
+
+import (
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "********"
+
+// Dump writes the effective merged config to w as YAML, with secrets
+// (the database password, JWT secret and Basic Auth passwords) redacted.
+func (c *Config) Dump(w io.Writer) error {
+	out := *c
+	if out.Database.Password != "" {
+		out.Database.Password = redacted
+	}
+	if out.Auth.JWTSecret != "" {
+		out.Auth.JWTSecret = redacted
+	}
+	if out.Auth.BasicUsers != nil {
+		// out is a shallow copy of *c, so out.Auth.BasicUsers still points
+		// at c's map; redact into a fresh one instead of mutating it.
+		users := make(map[string]string, len(out.Auth.BasicUsers))
+		for user := range out.Auth.BasicUsers {
+			users[user] = redacted
+		}
+		out.Auth.BasicUsers = users
+	}
+
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(out)
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDumpRedactsSecrets(t *testing.T) {
+	cfg := NewDefault()
+	cfg.Database.DBName = "orc"
+	cfg.Database.Password = "db-secret"
+	cfg.Auth.JWTSecret = "jwt-secret"
+	cfg.Auth.BasicUsers = map[string]string{"alice": "alice-pw"}
+
+	var buf bytes.Buffer
+	if err := cfg.Dump(&buf); err != nil {
+		t.Fatalf("Dump: %v", err)
+	}
+	out := buf.String()
+
+	for _, secret := range []string{"db-secret", "jwt-secret", "alice-pw"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("Dump output contains secret %q:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, redacted) {
+		t.Errorf("Dump output doesn't contain the redacted placeholder %q:\n%s", redacted, out)
+	}
+
+	if cfg.Auth.BasicUsers["alice"] != "alice-pw" {
+		t.Error("Dump mutated the live config's BasicUsers map")
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import "flag"
+
+// RegisterFlags binds common configuration knobs to fs so callers can
+// override file/env-derived values from the command line:
+//
+//	cfg := config.NewDefault()
+//	cfg.RegisterFlags(flag.CommandLine)
+//	flag.Parse()
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Database.Host, "db-host", c.Database.Host, "database host")
+	fs.IntVar(&c.Database.Port, "db-port", c.Database.Port, "database port")
+	fs.StringVar(&c.Database.User, "db-user", c.Database.User, "database user")
+	fs.StringVar(&c.Database.DBName, "db-name", c.Database.DBName, "database name")
+
+	fs.IntVar(&c.Server.Port, "port", c.Server.Port, "HTTP listen port")
+	fs.DurationVar(&c.Server.ShutdownTimeout, "shutdown-timeout", c.Server.ShutdownTimeout, "graceful shutdown timeout")
+	fs.DurationVar(&c.Server.RequestTimeout, "request-timeout", c.Server.RequestTimeout, "per-request timeout")
+
+	fs.IntVar(&c.Engine.WorkerCount, "worker-count", c.Engine.WorkerCount, "engine worker pool size")
+	fs.IntVar(&c.Engine.DefaultTimeout, "engine-timeout", c.Engine.DefaultTimeout, "default engine task timeout in seconds")
+}
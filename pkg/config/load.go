@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix is prepended to every environment variable consulted by Load,
+// e.g. ORC_DATABASE_HOST.
+const envPrefix = "ORC_"
+
+// Load builds a Config starting from NewDefault, overlaying the file at the
+// first non-empty path in paths (YAML or TOML, auto-detected by extension),
+// then applying ORC_-prefixed environment variables. A file that can't be
+// read or parsed is an error; later paths are not tried. The result is
+// validated before being returned.
+func Load(paths ...string) (*Config, error) {
+	cfg := NewDefault()
+
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		if err := loadFile(cfg, p); err != nil {
+			return nil, fmt.Errorf("config: loading %s: %w", p, err)
+		}
+		break
+	}
+
+	if err := applyEnv(cfg); err != nil {
+		return nil, fmt.Errorf("config: applying environment: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(data, cfg)
+	case ".toml":
+		return toml.Unmarshal(data, cfg)
+	default:
+		return fmt.Errorf("unsupported config extension %q", filepath.Ext(path))
+	}
+}
+
+func applyEnv(cfg *Config) error {
+	setString(&cfg.Database.Host, envPrefix+"DATABASE_HOST")
+	setString(&cfg.Database.User, envPrefix+"DATABASE_USER")
+	setString(&cfg.Database.Password, envPrefix+"DATABASE_PASSWORD")
+	setString(&cfg.Database.DBName, envPrefix+"DATABASE_DBNAME")
+	if err := setInt(&cfg.Database.Port, envPrefix+"DATABASE_PORT"); err != nil {
+		return err
+	}
+
+	if err := setInt(&cfg.Server.Port, envPrefix+"SERVER_PORT"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Server.ShutdownTimeout, envPrefix+"SERVER_SHUTDOWN_TIMEOUT"); err != nil {
+		return err
+	}
+	if err := setDuration(&cfg.Server.RequestTimeout, envPrefix+"SERVER_REQUEST_TIMEOUT"); err != nil {
+		return err
+	}
+
+	if err := setInt(&cfg.Engine.WorkerCount, envPrefix+"ENGINE_WORKER_COUNT"); err != nil {
+		return err
+	}
+	if err := setInt(&cfg.Engine.DefaultTimeout, envPrefix+"ENGINE_DEFAULT_TIMEOUT"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func setString(dst *string, key string) {
+	if v, ok := os.LookupEnv(key); ok {
+		*dst = v
+	}
+}
+
+func setInt(dst *int, key string) error {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*dst = n
+	return nil
+}
+
+func setDuration(dst *time.Duration, key string) error {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	*dst = d
+	return nil
+}
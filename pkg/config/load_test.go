@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestLoadEnvOverlay(t *testing.T) {
+	t.Setenv("ORC_DATABASE_HOST", "db.internal")
+	t.Setenv("ORC_DATABASE_PORT", "6543")
+	t.Setenv("ORC_SERVER_PORT", "8080")
+	t.Setenv("ORC_ENGINE_WORKER_COUNT", "8")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.Database.Host != "db.internal" {
+		t.Errorf("Database.Host = %q, want %q", cfg.Database.Host, "db.internal")
+	}
+	if cfg.Database.Port != 6543 {
+		t.Errorf("Database.Port = %d, want %d", cfg.Database.Port, 6543)
+	}
+	if cfg.Server.Port != 8080 {
+		t.Errorf("Server.Port = %d, want %d", cfg.Server.Port, 8080)
+	}
+	if cfg.Engine.WorkerCount != 8 {
+		t.Errorf("Engine.WorkerCount = %d, want %d", cfg.Engine.WorkerCount, 8)
+	}
+}
+
+func TestLoadEnvOverlayInvalidInt(t *testing.T) {
+	t.Setenv("ORC_SERVER_PORT", "not-a-port")
+
+	if _, err := Load(); err == nil {
+		t.Fatal("Load with a non-numeric ORC_SERVER_PORT returned nil error, want one")
+	}
+}
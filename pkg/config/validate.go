@@ -0,0 +1,21 @@
+package config
+
+import "fmt"
+
+// Validate rejects configurations that would leave the server or engine
+// unable to start.
+func (c *Config) Validate() error {
+	if c.Engine.WorkerCount <= 0 {
+		return fmt.Errorf("config: engine.worker_count must be > 0, got %d", c.Engine.WorkerCount)
+	}
+	if c.Engine.DefaultTimeout <= 0 {
+		return fmt.Errorf("config: engine.default_timeout must be > 0, got %d", c.Engine.DefaultTimeout)
+	}
+	if c.Database.DBName == "" {
+		return fmt.Errorf("config: database.dbname must not be empty")
+	}
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		return fmt.Errorf("config: server.port must be between 1 and 65535, got %d", c.Server.Port)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	base := func() *Config {
+		cfg := NewDefault()
+		cfg.Database.DBName = "orc"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr bool
+	}{
+		{"valid default", func(*Config) {}, false},
+		{"zero worker count", func(c *Config) { c.Engine.WorkerCount = 0 }, true},
+		{"negative worker count", func(c *Config) { c.Engine.WorkerCount = -1 }, true},
+		{"zero default timeout", func(c *Config) { c.Engine.DefaultTimeout = 0 }, true},
+		{"empty dbname", func(c *Config) { c.Database.DBName = "" }, true},
+		{"port zero", func(c *Config) { c.Server.Port = 0 }, true},
+		{"port too large", func(c *Config) { c.Server.Port = 70000 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base()
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}